@@ -0,0 +1,179 @@
+package tui
+
+import "image"
+
+type orientation int
+
+const (
+	horizontal orientation = iota
+	vertical
+)
+
+var _ Widget = &Box{}
+
+// Box lays out a list of child widgets along a single axis, optionally
+// surrounded by a border.
+type Box struct {
+	WidgetBase
+
+	orientation orientation
+	children    []Widget
+
+	border bool
+	title  string
+}
+
+// NewHBox returns a Box that arranges its children left-to-right.
+func NewHBox(children ...Widget) *Box {
+	return &Box{orientation: horizontal, children: children}
+}
+
+// NewVBox returns a Box that arranges its children top-to-bottom.
+func NewVBox(children ...Widget) *Box {
+	return &Box{orientation: vertical, children: children}
+}
+
+// Append adds a widget to the end of the box.
+func (b *Box) Append(w Widget) {
+	b.children = append(b.children, w)
+}
+
+// SetBorder sets whether the box draws a border around its children.
+func (b *Box) SetBorder(enabled bool) {
+	b.border = enabled
+}
+
+// SetTitle sets the text drawn in the box's border.
+func (b *Box) SetTitle(title string) {
+	b.title = title
+}
+
+// SizeHint returns the sum of the children's SizeHint along the box's
+// axis, plus room for the border.
+func (b *Box) SizeHint() image.Point {
+	var size image.Point
+	for _, c := range b.children {
+		h := c.SizeHint()
+		if b.orientation == horizontal {
+			size.X += h.X
+			if h.Y > size.Y {
+				size.Y = h.Y
+			}
+		} else {
+			size.Y += h.Y
+			if h.X > size.X {
+				size.X = h.X
+			}
+		}
+	}
+	if b.border {
+		size = size.Add(image.Point{2, 2})
+	}
+	return size
+}
+
+// Draw draws the box's border, if any, then lays out and draws its
+// children within the remaining space.
+func (b *Box) Draw(p *Painter) {
+	size := b.Size()
+
+	content := size
+	dx, dy := 0, 0
+	if b.border {
+		b.drawBorder(p, size)
+		content = image.Point{size.X - 2, size.Y - 2}
+		dx, dy = 1, 1
+	}
+
+	b.layout(content)
+
+	p.Translate(dx, dy)
+	var advance image.Point
+	for _, c := range b.children {
+		p.Translate(advance.X, advance.Y)
+		c.Draw(p)
+		p.Restore(advance.X, advance.Y)
+
+		if b.orientation == horizontal {
+			advance.X += c.Size().X
+		} else {
+			advance.Y += c.Size().Y
+		}
+	}
+	p.Restore(dx, dy)
+}
+
+func (b *Box) drawBorder(p *Painter, size image.Point) {
+	p.DrawRune(0, 0, '┌')
+	p.DrawRune(size.X-1, 0, '┐')
+	p.DrawRune(0, size.Y-1, '└')
+	p.DrawRune(size.X-1, size.Y-1, '┘')
+	for x := 1; x < size.X-1; x++ {
+		p.DrawRune(x, 0, '─')
+		p.DrawRune(x, size.Y-1, '─')
+	}
+	for y := 1; y < size.Y-1; y++ {
+		p.DrawRune(0, y, '│')
+		p.DrawRune(size.X-1, y, '│')
+	}
+}
+
+// layout assigns each child a size along the box's axis: Expanding
+// children split whatever space is left after Preferred and Minimum
+// children take their SizeHint.
+func (b *Box) layout(size image.Point) {
+	if len(b.children) == 0 {
+		return
+	}
+
+	if b.orientation == horizontal {
+		var fixed, expanding int
+		for _, c := range b.children {
+			x, _ := c.SizePolicy()
+			if x == Expanding {
+				expanding++
+			} else {
+				fixed += c.SizeHint().X
+			}
+		}
+
+		var share int
+		if extra := size.X - fixed; expanding > 0 && extra > 0 {
+			share = extra / expanding
+		}
+
+		for _, c := range b.children {
+			x, _ := c.SizePolicy()
+			w := c.SizeHint().X
+			if x == Expanding {
+				w = share
+			}
+			c.Resize(image.Point{w, size.Y})
+		}
+		return
+	}
+
+	var fixed, expanding int
+	for _, c := range b.children {
+		_, y := c.SizePolicy()
+		if y == Expanding {
+			expanding++
+		} else {
+			fixed += c.SizeHint().Y
+		}
+	}
+
+	var share int
+	if extra := size.Y - fixed; expanding > 0 && extra > 0 {
+		share = extra / expanding
+	}
+
+	for _, c := range b.children {
+		_, y := c.SizePolicy()
+		h := c.SizeHint().Y
+		if y == Expanding {
+			h = share
+		}
+		c.Resize(image.Point{size.X, h})
+	}
+}
@@ -0,0 +1,705 @@
+package tui
+
+import (
+	"image"
+	"time"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+var _ Widget = &Entry{}
+
+// defaultUndoCoalesceInterval is how long a caller has between edits for
+// them to still be grouped into the same undo step.
+const defaultUndoCoalesceInterval = 500 * time.Millisecond
+
+// entrySnapshot is the undo/redo unit: the full state needed to restore
+// an Entry to a point in its edit history.
+type entrySnapshot struct {
+	text   []rune
+	cursor int
+	anchor int
+}
+
+// EchoMode controls how Entry renders its contents. Applications use
+// EchoPassword to build password prompts.
+type EchoMode int
+
+const (
+	// EchoNormal displays the text as typed. This is the default.
+	EchoNormal EchoMode = iota
+	// EchoPassword displays MaskRune once per underlying rune, while
+	// Text still returns the real content.
+	EchoPassword
+	// EchoNoEcho displays nothing at all, though keystrokes are still
+	// captured.
+	EchoNoEcho
+)
+
+// Clipboard is implemented by types that can store and retrieve text cut
+// or copied from an Entry. Applications can provide their own
+// implementation to wire up the OS clipboard.
+type Clipboard interface {
+	SetText(text string)
+	Text() string
+}
+
+// SimpleClipboard is an in-memory Clipboard. It is the default used by a
+// new Entry.
+type SimpleClipboard struct {
+	text string
+}
+
+// SetText stores text in the clipboard.
+func (c *SimpleClipboard) SetText(text string) {
+	c.text = text
+}
+
+// Text returns the text currently stored in the clipboard.
+func (c *SimpleClipboard) Text() string {
+	return c.text
+}
+
+// Entry is a one-line text editor.
+type Entry struct {
+	WidgetBase
+
+	text []rune
+
+	// cursor is the rune offset of the insertion point. anchor is the
+	// other end of the current selection; anchor == cursor means there
+	// is no selection.
+	cursor int
+	anchor int
+
+	clipboard Clipboard
+
+	echoMode EchoMode
+	maskRune rune
+
+	validator func(string) error
+
+	completer       func(prefix string) []string
+	completions     []string
+	completionIndex int
+
+	undoStack            []entrySnapshot
+	redoStack            []entrySnapshot
+	undoGroupOpen        bool
+	lastEditAt           time.Time
+	undoCoalesceInterval time.Duration
+
+	onChanged            func(*Entry)
+	onSubmit             func(*Entry)
+	onCursorChanged      func(*Entry)
+	onCompletionSelected func(*Entry, string)
+}
+
+// NewEntry returns a new Entry.
+func NewEntry() *Entry {
+	return &Entry{
+		clipboard:            &SimpleClipboard{},
+		maskRune:             '*',
+		undoCoalesceInterval: defaultUndoCoalesceInterval,
+	}
+}
+
+// SetEchoMode sets how the entry renders its contents. The default is
+// EchoNormal.
+func (e *Entry) SetEchoMode(mode EchoMode) {
+	e.echoMode = mode
+}
+
+// SetMaskRune sets the rune drawn for each underlying character when the
+// entry is in EchoPassword mode. The default is '*'.
+func (e *Entry) SetMaskRune(r rune) {
+	e.maskRune = r
+}
+
+// SetValidator sets the function used to validate the entry's contents.
+// Invalid text is still displayed, styled with the "entry.invalid"
+// theme style, and blocks OnSubmit until it becomes valid.
+func (e *Entry) SetValidator(fn func(string) error) {
+	e.validator = fn
+}
+
+// Valid reports whether the entry's current contents satisfy the
+// validator. An entry with no validator is always valid.
+func (e *Entry) Valid() bool {
+	if e.validator == nil {
+		return true
+	}
+	return e.validator(e.Text()) == nil
+}
+
+// SetCompleter sets the function used to compute completion candidates
+// for the text before the cursor. Whenever it returns candidates and the
+// entry is focused, Draw floats a selectable popup below the entry,
+// navigable with Tab and Shift+Tab and accepted with Enter.
+func (e *Entry) SetCompleter(fn func(prefix string) []string) {
+	e.completer = fn
+	e.refreshCompletions()
+}
+
+// OnCompletionSelected sets a handler that's invoked when the user
+// accepts a completion candidate.
+func (e *Entry) OnCompletionSelected(fn func(*Entry, string)) {
+	e.onCompletionSelected = fn
+}
+
+// SetFocused sets whether the entry has keyboard focus. Losing or
+// gaining focus always starts a new undo group.
+func (e *Entry) SetFocused(focused bool) {
+	e.undoGroupOpen = false
+	e.WidgetBase.SetFocused(focused)
+}
+
+// SetUndoCoalesceInterval sets how long a caller has between edits for
+// them to still be grouped into the same undo step. The default is
+// 500ms, which gives word-granularity undo for normal typing speeds.
+func (e *Entry) SetUndoCoalesceInterval(d time.Duration) {
+	e.undoCoalesceInterval = d
+}
+
+// Undo reverts the entry to the state it was in before the most recent
+// undo group, if any.
+func (e *Entry) Undo() {
+	if !e.CanUndo() {
+		return
+	}
+	e.redoStack = append(e.redoStack, e.snapshot())
+	snap := e.undoStack[len(e.undoStack)-1]
+	e.undoStack = e.undoStack[:len(e.undoStack)-1]
+	e.restore(snap)
+	e.undoGroupOpen = false
+}
+
+// Redo reapplies the most recently undone edit, if any.
+func (e *Entry) Redo() {
+	if !e.CanRedo() {
+		return
+	}
+	e.undoStack = append(e.undoStack, e.snapshot())
+	snap := e.redoStack[len(e.redoStack)-1]
+	e.redoStack = e.redoStack[:len(e.redoStack)-1]
+	e.restore(snap)
+	e.undoGroupOpen = false
+}
+
+// CanUndo reports whether Undo would have an effect.
+func (e *Entry) CanUndo() bool {
+	return len(e.undoStack) > 0
+}
+
+// CanRedo reports whether Redo would have an effect.
+func (e *Entry) CanRedo() bool {
+	return len(e.redoStack) > 0
+}
+
+// Text returns the contents of the entry.
+func (e *Entry) Text() string {
+	return string(e.text)
+}
+
+// SetText sets the contents of the entry and moves the cursor to the
+// end of the new text.
+func (e *Entry) SetText(text string) {
+	e.text = []rune(text)
+	e.cursor = len(e.text)
+	e.anchor = e.cursor
+	e.undoStack = nil
+	e.redoStack = nil
+	e.undoGroupOpen = false
+	e.notifyChanged()
+	e.notifyCursorChanged()
+}
+
+// SetCursor moves the cursor to the given rune offset, clamping it to
+// the bounds of the text, and clears any active selection.
+func (e *Entry) SetCursor(pos int) {
+	e.setCursorPos(pos, false)
+}
+
+// CursorPos returns the current cursor position as a rune offset.
+func (e *Entry) CursorPos() int {
+	return e.cursor
+}
+
+// Selection returns the start and end rune offsets of the current
+// selection. When there is no selection, start and end both equal
+// CursorPos.
+func (e *Entry) Selection() (start, end int) {
+	if e.anchor < e.cursor {
+		return e.anchor, e.cursor
+	}
+	return e.cursor, e.anchor
+}
+
+// SetClipboard installs the Clipboard used by Cut, Copy and Paste,
+// replacing the in-memory default.
+func (e *Entry) SetClipboard(c Clipboard) {
+	e.clipboard = c
+}
+
+// Cut removes the selected text, if any, and places it on the clipboard.
+func (e *Entry) Cut() {
+	if !e.hasSelection() {
+		return
+	}
+	e.Copy()
+	e.deleteSelection()
+}
+
+// Copy places the selected text, if any, on the clipboard.
+func (e *Entry) Copy() {
+	if !e.hasSelection() {
+		return
+	}
+	start, end := e.Selection()
+	e.clipboard.SetText(string(e.text[start:end]))
+}
+
+// Paste inserts the clipboard contents at the cursor, replacing the
+// selection if there is one.
+func (e *Entry) Paste() {
+	e.insert([]rune(e.clipboard.Text()))
+}
+
+// OnChanged sets a handler that's invoked whenever the contents of the
+// entry change.
+func (e *Entry) OnChanged(fn func(*Entry)) {
+	e.onChanged = fn
+}
+
+// OnSubmit sets a handler that's invoked when the user presses Enter
+// while the entry is focused.
+func (e *Entry) OnSubmit(fn func(*Entry)) {
+	e.onSubmit = fn
+}
+
+// OnCursorChanged sets a handler that's invoked whenever the cursor
+// position or selection changes.
+func (e *Entry) OnCursorChanged(fn func(*Entry)) {
+	e.onCursorChanged = fn
+}
+
+// SizeHint returns the preferred size of the entry.
+func (e *Entry) SizeHint() image.Point {
+	return image.Point{10, 1}
+}
+
+// OnEvent handles a key event, moving the cursor, editing the text, or
+// triggering the submit handler. Events are ignored unless the entry is
+// focused.
+func (e *Entry) OnEvent(ev Event) {
+	if !e.IsFocused() || ev.Type != EventKey {
+		return
+	}
+
+	extend := ev.Mod&ModShift != 0
+
+	switch ev.Key {
+	case KeyEnter:
+		if len(e.completions) > 0 {
+			e.acceptCompletion()
+			return
+		}
+		if e.Valid() && e.onSubmit != nil {
+			e.onSubmit(e)
+		}
+		return
+	case KeyTab:
+		if len(e.completions) == 0 {
+			return
+		}
+		if extend {
+			e.completionIndex = (e.completionIndex - 1 + len(e.completions)) % len(e.completions)
+		} else {
+			e.completionIndex = (e.completionIndex + 1) % len(e.completions)
+		}
+		return
+	case KeyArrowLeft:
+		e.setCursorPos(e.cursor-1, extend)
+		return
+	case KeyArrowRight:
+		e.setCursorPos(e.cursor+1, extend)
+		return
+	case KeyCtrlLeft:
+		e.setCursorPos(e.prevWordBoundary(e.cursor), extend)
+		return
+	case KeyCtrlRight:
+		e.setCursorPos(e.nextWordBoundary(e.cursor), extend)
+		return
+	case KeyHome:
+		e.setCursorPos(0, extend)
+		return
+	case KeyEnd:
+		e.setCursorPos(len(e.text), extend)
+		return
+	case KeyBackspace, KeyBackspace2:
+		e.backspace()
+		return
+	case KeyDelete:
+		e.delete()
+		return
+	case KeyCtrlW:
+		e.deleteWordBackward()
+		return
+	case KeyCtrlU:
+		e.deleteToStart()
+		return
+	case KeyCtrlZ:
+		if extend {
+			e.Redo()
+		} else {
+			e.Undo()
+		}
+		return
+	case KeyCtrlY:
+		e.Redo()
+		return
+	}
+
+	if ev.Ch != 0 {
+		e.insert([]rune{ev.Ch})
+	}
+}
+
+// Draw draws the entry. A focused entry scrolls the visible window so
+// that the cursor is always on screen; an unfocused one shows the tail
+// of the text.
+func (e *Entry) Draw(p *Painter) {
+	style := "entry"
+	switch {
+	case !e.Valid():
+		style = "entry.invalid"
+	case e.IsFocused():
+		style = "entry.focused"
+	}
+
+	size := e.Size()
+
+	p.WithStyle(style, func(p *Painter) {
+		p.FillRect(0, 0, size.X, 1)
+
+		if e.echoMode == EchoNoEcho {
+			if e.IsFocused() {
+				p.DrawCursor(0, 0)
+			}
+			return
+		}
+
+		display := e.displayRunes()
+		start := e.scrollOffset(display, size.X)
+
+		x := 0
+		for i := start; i < len(display) && x < size.X; {
+			text, w, next := nextCluster(display, i)
+			p.DrawCluster(x, 0, text)
+			x += w
+			i = next
+		}
+
+		if e.IsFocused() {
+			cursorX := runewidth.StringWidth(string(display[start:e.cursor]))
+			p.DrawCursor(cursorX, 0)
+		}
+	})
+
+	if e.IsFocused() && len(e.completions) > 0 {
+		e.drawCompletions(p, size)
+	}
+}
+
+// drawCompletions floats the completion popup directly below the entry,
+// highlighting the currently selected candidate.
+func (e *Entry) drawCompletions(p *Painter, size image.Point) {
+	width := size.X
+	for _, c := range e.completions {
+		if w := runewidth.StringWidth(c); w > width {
+			width = w
+		}
+	}
+
+	// The entry only ever draws a single content row, regardless of how
+	// tall it was resized, so the popup always floats one row below that
+	// row rather than below the full widget height.
+	pos := p.Offset().Add(image.Point{0, 1})
+
+	p.DrawOverlay(pos, func(p *Painter) {
+		for i, c := range e.completions {
+			style := "entry.completion"
+			if i == e.completionIndex {
+				style = "entry.completion.selected"
+			}
+			p.WithStyle(style, func(p *Painter) {
+				p.FillRect(0, i, width, 1)
+				p.DrawText(0, i, c)
+			})
+		}
+	})
+}
+
+// displayRunes returns the runes Draw should render: the real text in
+// EchoNormal, or one MaskRune per underlying rune in EchoPassword.
+func (e *Entry) displayRunes() []rune {
+	if e.echoMode != EchoPassword {
+		return e.text
+	}
+
+	mask := make([]rune, len(e.text))
+	for i := range mask {
+		mask[i] = e.maskRune
+	}
+	return mask
+}
+
+// scrollOffset returns the rune index of the first visible character of
+// display. An unfocused entry always shows the tail of the text; a
+// focused entry scrolls to keep the cursor visible. Both walk runes
+// accumulating runewidth.RuneWidth, matching how Draw advances its own
+// column counter, so double-width and zero-width runes scroll the same
+// way they're drawn.
+func (e *Entry) scrollOffset(display []rune, width int) int {
+	if !e.IsFocused() {
+		return tailRuneOffset(display, width)
+	}
+	return cursorRuneOffset(display, e.cursor, width)
+}
+
+// tailRuneOffset returns the smallest rune index i such that
+// display[i:] still fits within width columns.
+func tailRuneOffset(display []rune, width int) int {
+	col, i := 0, len(display)
+	for i > 0 {
+		w := runewidth.RuneWidth(display[i-1])
+		if col+w > width {
+			break
+		}
+		col += w
+		i--
+	}
+	return i
+}
+
+// cursorRuneOffset returns the smallest rune index i such that
+// display[i:cursor] fits within width-1 columns, leaving room for the
+// cursor's own column.
+func cursorRuneOffset(display []rune, cursor, width int) int {
+	col, i := 0, cursor
+	for i > 0 {
+		w := runewidth.RuneWidth(display[i-1])
+		if col+w > width-1 {
+			break
+		}
+		col += w
+		i--
+	}
+	return i
+}
+
+func (e *Entry) hasSelection() bool {
+	return e.anchor != e.cursor
+}
+
+func (e *Entry) notifyChanged() {
+	e.refreshCompletions()
+	if e.onChanged != nil {
+		e.onChanged(e)
+	}
+}
+
+// refreshCompletions recomputes the completion candidates for the text
+// before the cursor.
+func (e *Entry) refreshCompletions() {
+	if e.completer == nil {
+		e.completions = nil
+		e.completionIndex = 0
+		return
+	}
+	e.completions = e.completer(string(e.text[:e.cursor]))
+	e.completionIndex = 0
+}
+
+// acceptCompletion replaces the text before the cursor with the
+// selected completion candidate.
+func (e *Entry) acceptCompletion() {
+	if e.completionIndex < 0 || e.completionIndex >= len(e.completions) {
+		return
+	}
+	choice := e.completions[e.completionIndex]
+
+	e.beginEdit()
+
+	rest := e.text[e.cursor:]
+	text := make([]rune, 0, len(choice)+len(rest))
+	text = append(text, []rune(choice)...)
+	text = append(text, rest...)
+	e.text = text
+
+	e.cursor = len([]rune(choice))
+	e.anchor = e.cursor
+
+	e.completions = nil
+	e.completionIndex = 0
+
+	if e.onCompletionSelected != nil {
+		e.onCompletionSelected(e, choice)
+	}
+	e.notifyChanged()
+	e.notifyCursorChanged()
+}
+
+func (e *Entry) notifyCursorChanged() {
+	if e.onCursorChanged != nil {
+		e.onCursorChanged(e)
+	}
+}
+
+func (e *Entry) setCursorPos(pos int, extend bool) {
+	e.cursor = clampInt(pos, 0, len(e.text))
+	if !extend {
+		e.anchor = e.cursor
+	}
+	e.undoGroupOpen = false
+	e.notifyCursorChanged()
+}
+
+// beginEdit opens a new undo group unless the previous edit is still
+// eligible to coalesce with this one: it happened inside the same
+// undo group and within undoCoalesceInterval.
+func (e *Entry) beginEdit() {
+	now := time.Now()
+	if !e.undoGroupOpen || now.Sub(e.lastEditAt) > e.undoCoalesceInterval {
+		e.pushUndo()
+	}
+	e.undoGroupOpen = true
+	e.lastEditAt = now
+}
+
+// pushUndo records the entry's current state as an undo step and
+// discards any redo history, since it's no longer reachable.
+func (e *Entry) pushUndo() {
+	e.undoStack = append(e.undoStack, e.snapshot())
+	e.redoStack = nil
+}
+
+func (e *Entry) snapshot() entrySnapshot {
+	return entrySnapshot{
+		text:   append([]rune{}, e.text...),
+		cursor: e.cursor,
+		anchor: e.anchor,
+	}
+}
+
+func (e *Entry) restore(s entrySnapshot) {
+	e.text = append([]rune{}, s.text...)
+	e.cursor = s.cursor
+	e.anchor = s.anchor
+	e.notifyChanged()
+	e.notifyCursorChanged()
+}
+
+func (e *Entry) deleteSelection() {
+	e.beginEdit()
+	start, end := e.Selection()
+	e.text = append(e.text[:start], e.text[end:]...)
+	e.cursor = start
+	e.anchor = start
+	e.notifyChanged()
+	e.notifyCursorChanged()
+}
+
+func (e *Entry) insert(s []rune) {
+	if len(s) == 0 {
+		return
+	}
+	e.beginEdit()
+	if e.hasSelection() {
+		e.deleteSelection()
+	}
+
+	text := make([]rune, 0, len(e.text)+len(s))
+	text = append(text, e.text[:e.cursor]...)
+	text = append(text, s...)
+	text = append(text, e.text[e.cursor:]...)
+	e.text = text
+
+	e.cursor += len(s)
+	e.anchor = e.cursor
+	e.notifyChanged()
+	e.notifyCursorChanged()
+}
+
+func (e *Entry) backspace() {
+	if e.hasSelection() {
+		e.deleteSelection()
+		return
+	}
+	if e.cursor == 0 {
+		return
+	}
+	e.beginEdit()
+	e.text = append(e.text[:e.cursor-1], e.text[e.cursor:]...)
+	e.cursor--
+	e.anchor = e.cursor
+	e.notifyChanged()
+	e.notifyCursorChanged()
+}
+
+func (e *Entry) delete() {
+	if e.hasSelection() {
+		e.deleteSelection()
+		return
+	}
+	if e.cursor >= len(e.text) {
+		return
+	}
+	e.beginEdit()
+	e.text = append(e.text[:e.cursor], e.text[e.cursor+1:]...)
+	e.notifyChanged()
+}
+
+func (e *Entry) deleteWordBackward() {
+	e.beginEdit()
+	start := e.prevWordBoundary(e.cursor)
+	e.text = append(e.text[:start], e.text[e.cursor:]...)
+	e.cursor = start
+	e.anchor = start
+	e.notifyChanged()
+	e.notifyCursorChanged()
+}
+
+func (e *Entry) deleteToStart() {
+	e.beginEdit()
+	e.text = append([]rune{}, e.text[e.cursor:]...)
+	e.cursor = 0
+	e.anchor = 0
+	e.notifyChanged()
+	e.notifyCursorChanged()
+}
+
+func (e *Entry) prevWordBoundary(pos int) int {
+	for pos > 0 && isSpace(e.text[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !isSpace(e.text[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+func (e *Entry) nextWordBoundary(pos int) int {
+	n := len(e.text)
+	for pos < n && isSpace(e.text[pos]) {
+		pos++
+	}
+	for pos < n && !isSpace(e.text[pos]) {
+		pos++
+	}
+	return pos
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t'
+}
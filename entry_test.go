@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"image"
 	"testing"
 )
@@ -109,6 +110,17 @@ dolor sit amet
 ...............
 `,
 	},
+	{
+		test: "Scrolling entry of double-width runes when focused",
+		size: image.Point{10, 5},
+		setup: func() *Entry {
+			e := NewEntry()
+			e.SetText("一二三四五六七八九十十一十二")
+			e.SetFocused(true)
+			return e
+		},
+		want: "\n十 一 十 二   \n..........\n..........\n..........\n..........\n",
+	},
 }
 
 func TestEntry_Draw(t *testing.T) {
@@ -135,6 +147,66 @@ func TestEntry_Draw(t *testing.T) {
 	}
 }
 
+var drawEntryEchoTests = []struct {
+	test  string
+	size  image.Point
+	setup func() *Entry
+	want  string
+}{
+	{
+		test: "EchoPassword masks short text",
+		size: image.Point{15, 5},
+		setup: func() *Entry {
+			e := NewEntry()
+			e.SetEchoMode(EchoPassword)
+			e.SetText("secret")
+			return e
+		},
+		want: "\n******         \n...............\n...............\n...............\n...............\n",
+	},
+	{
+		test: "EchoNoEcho draws nothing",
+		size: image.Point{15, 5},
+		setup: func() *Entry {
+			e := NewEntry()
+			e.SetEchoMode(EchoNoEcho)
+			e.SetText("secret")
+			return e
+		},
+		want: "\n               \n...............\n...............\n...............\n...............\n",
+	},
+	{
+		test: "EchoPassword scrolls a masked buffer longer than the visible width",
+		size: image.Point{15, 5},
+		setup: func() *Entry {
+			e := NewEntry()
+			e.SetEchoMode(EchoPassword)
+			e.SetText("Lorem ipsum dolor sit amet")
+			e.SetFocused(true)
+			return e
+		},
+		want: "\n************** \n...............\n...............\n...............\n...............\n",
+	},
+}
+
+func TestEntry_EchoMode(t *testing.T) {
+	for _, tt := range drawEntryEchoTests {
+		tt := tt
+		t.Run(tt.test, func(t *testing.T) {
+			surface := newTestSurface(tt.size.X, tt.size.Y)
+			painter := NewPainter(surface, NewTheme())
+
+			e := tt.setup()
+			e.Resize(surface.size)
+			e.Draw(painter)
+
+			if surface.String() != tt.want {
+				t.Errorf("got = \n%s\n\nwant = \n%s", surface.String(), tt.want)
+			}
+		})
+	}
+}
+
 func TestEntry_OnChanged(t *testing.T) {
 	e := NewEntry()
 
@@ -372,3 +444,369 @@ func TestEntry_Layout(t *testing.T) {
 		})
 	}
 }
+
+var cursorEntryTests = []struct {
+	test   string
+	size   image.Point
+	setup  func() *Entry
+	cursor image.Point
+}{
+	{
+		test: "Cursor at end of short text",
+		size: image.Point{15, 1},
+		setup: func() *Entry {
+			e := NewEntry()
+			e.SetText("test")
+			e.SetFocused(true)
+			return e
+		},
+		cursor: image.Point{4, 0},
+	},
+	{
+		test: "Cursor after Home",
+		size: image.Point{15, 1},
+		setup: func() *Entry {
+			e := NewEntry()
+			e.SetText("test")
+			e.SetFocused(true)
+			e.OnEvent(Event{Type: EventKey, Key: KeyHome})
+			return e
+		},
+		cursor: image.Point{0, 0},
+	},
+	{
+		test: "Cursor after Left Left",
+		size: image.Point{15, 1},
+		setup: func() *Entry {
+			e := NewEntry()
+			e.SetText("test")
+			e.SetFocused(true)
+			e.OnEvent(Event{Type: EventKey, Key: KeyArrowLeft})
+			e.OnEvent(Event{Type: EventKey, Key: KeyArrowLeft})
+			return e
+		},
+		cursor: image.Point{2, 0},
+	},
+	{
+		test: "Cursor follows scroll when text is longer than the visible width",
+		size: image.Point{15, 1},
+		setup: func() *Entry {
+			e := NewEntry()
+			e.SetFocused(true)
+			e.SetText("Lorem ipsum dolor sit amet")
+			return e
+		},
+		cursor: image.Point{14, 0},
+	},
+	{
+		test: "Cursor stays in bounds with double-width runes",
+		size:  image.Point{10, 1},
+		setup: func() *Entry {
+			e := NewEntry()
+			e.SetFocused(true)
+			e.SetText("一二三四五六七八九十十一十二")
+			return e
+		},
+		cursor: image.Point{8, 0},
+	},
+}
+
+func TestEntry_Cursor(t *testing.T) {
+	for _, tt := range cursorEntryTests {
+		tt := tt
+		t.Run(tt.test, func(t *testing.T) {
+			surface := newTestSurface(tt.size.X, tt.size.Y)
+			painter := NewPainter(surface, NewTheme())
+
+			e := tt.setup()
+			e.Resize(surface.size)
+			e.Draw(painter)
+
+			if surface.cursor != tt.cursor {
+				t.Errorf("surface.cursor = %s; want = %s", surface.cursor, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestEntry_Selection(t *testing.T) {
+	e := NewEntry()
+	e.SetText("hello world")
+	e.SetFocused(true)
+
+	e.SetCursor(0)
+	for i := 0; i < 5; i++ {
+		e.OnEvent(Event{Type: EventKey, Key: KeyArrowRight, Mod: ModShift})
+	}
+
+	start, end := e.Selection()
+	if start != 0 || end != 5 {
+		t.Errorf("e.Selection() = (%d, %d); want = (0, 5)", start, end)
+	}
+
+	e.Copy()
+	if got := e.clipboard.Text(); got != "hello" {
+		t.Errorf("clipboard.Text() = %s; want = %s", got, "hello")
+	}
+
+	e.Cut()
+	if got := e.Text(); got != " world" {
+		t.Errorf("e.Text() = %s; want = %s", got, " world")
+	}
+
+	e.SetCursor(0)
+	e.Paste()
+	if got := e.Text(); got != "hello world" {
+		t.Errorf("e.Text() = %s; want = %s", got, "hello world")
+	}
+}
+
+func TestEntry_Validator(t *testing.T) {
+	e := NewEntry()
+	e.SetFocused(true)
+	e.SetValidator(func(s string) error {
+		if s == "" {
+			return errors.New("must not be empty")
+		}
+		return nil
+	})
+
+	if e.Valid() {
+		t.Errorf("e.Valid() = true; want = false for empty text")
+	}
+
+	var submitted bool
+	e.OnSubmit(func(e *Entry) {
+		submitted = true
+	})
+
+	e.OnEvent(Event{Type: EventKey, Key: KeyEnter})
+	if submitted {
+		t.Errorf("an invalid entry should not submit")
+	}
+
+	e.SetText("ok")
+	if !e.Valid() {
+		t.Errorf("e.Valid() = false; want = true for non-empty text")
+	}
+
+	e.OnEvent(Event{Type: EventKey, Key: KeyEnter})
+	if !submitted {
+		t.Errorf("a valid entry should submit")
+	}
+}
+
+func TestEntry_Draw_Invalid(t *testing.T) {
+	e := NewEntry()
+	e.SetValidator(func(s string) error {
+		if s == "" {
+			return errors.New("required")
+		}
+		return nil
+	})
+
+	surface := newTestSurface(10, 1)
+	painter := NewPainter(surface, NewTheme())
+
+	e.Resize(surface.size)
+	e.Draw(painter)
+
+	want := NewTheme().Style("entry.invalid")
+	if got := surface.cells[image.Point{0, 0}].Style; got != want {
+		t.Errorf("surface.cells[0,0].Style = %+v; want = %+v", got, want)
+	}
+}
+
+var drawEntryCompletionTests = []struct {
+	test  string
+	size  image.Point
+	setup func() *Entry
+	want  string
+}{
+	{
+		test: "Completion popup floats below the entry",
+		size: image.Point{15, 5},
+		setup: func() *Entry {
+			e := NewEntry()
+			e.SetFocused(true)
+			e.SetCompleter(func(prefix string) []string {
+				if prefix == "te" {
+					return []string{"test", "team"}
+				}
+				return nil
+			})
+			e.SetText("te")
+			return e
+		},
+		want: "\nte             \ntest           \nteam           \n...............\n...............\n",
+	},
+}
+
+func TestEntry_DrawCompletions(t *testing.T) {
+	for _, tt := range drawEntryCompletionTests {
+		tt := tt
+		t.Run(tt.test, func(t *testing.T) {
+			surface := newTestSurface(tt.size.X, tt.size.Y)
+			painter := NewPainter(surface, NewTheme())
+
+			e := tt.setup()
+			e.Resize(surface.size)
+			e.Draw(painter)
+
+			if surface.String() != tt.want {
+				t.Errorf("got = \n%s\n\nwant = \n%s", surface.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEntry_Completion(t *testing.T) {
+	e := NewEntry()
+	e.SetFocused(true)
+	e.SetCompleter(func(prefix string) []string {
+		if prefix == "te" {
+			return []string{"test", "team"}
+		}
+		return nil
+	})
+	e.SetText("te")
+
+	var selected string
+	e.OnCompletionSelected(func(e *Entry, s string) {
+		selected = s
+	})
+
+	e.OnEvent(Event{Type: EventKey, Key: KeyTab})
+	e.OnEvent(Event{Type: EventKey, Key: KeyEnter})
+
+	if selected != "team" {
+		t.Errorf("selected completion = %s; want = %s", selected, "team")
+	}
+	if e.Text() != "team" {
+		t.Errorf("e.Text() = %s; want = %s", e.Text(), "team")
+	}
+}
+
+func TestEntry_UndoRedo(t *testing.T) {
+	surface := newTestSurface(15, 5)
+	painter := NewPainter(surface, NewTheme())
+
+	e := NewEntry()
+	e.SetFocused(true)
+	e.Resize(surface.size)
+
+	for _, ch := range "hello" {
+		e.OnEvent(Event{Type: EventKey, Ch: ch})
+	}
+
+	// Moving the cursor breaks the undo group, so "hello" and " world"
+	// become separate undo steps even though nothing else slows down
+	// between them.
+	e.OnEvent(Event{Type: EventKey, Key: KeyArrowLeft})
+	e.OnEvent(Event{Type: EventKey, Key: KeyArrowRight})
+
+	for _, ch := range " world" {
+		e.OnEvent(Event{Type: EventKey, Ch: ch})
+	}
+
+	if got := e.Text(); got != "hello world" {
+		t.Fatalf("e.Text() = %s; want = %s", got, "hello world")
+	}
+	if e.CanRedo() {
+		t.Fatalf("e.CanRedo() = true; want = false before any Undo")
+	}
+
+	e.Draw(painter)
+	want := "\nhello world    \n...............\n...............\n...............\n...............\n"
+	if surface.String() != want {
+		t.Errorf("got = \n%s\n\nwant = \n%s", surface.String(), want)
+	}
+	if surface.cursor != (image.Point{11, 0}) {
+		t.Errorf("surface.cursor = %s; want = %s", surface.cursor, image.Point{11, 0})
+	}
+
+	e.Undo()
+	if got := e.Text(); got != "hello" {
+		t.Fatalf("after Undo, e.Text() = %s; want = %s", got, "hello")
+	}
+	if got := e.CursorPos(); got != 5 {
+		t.Fatalf("after Undo, e.CursorPos() = %d; want = %d", got, 5)
+	}
+
+	e.Draw(painter)
+	want = "\nhello          \n...............\n...............\n...............\n...............\n"
+	if surface.String() != want {
+		t.Errorf("got = \n%s\n\nwant = \n%s", surface.String(), want)
+	}
+	if surface.cursor != (image.Point{5, 0}) {
+		t.Errorf("surface.cursor = %s; want = %s", surface.cursor, image.Point{5, 0})
+	}
+
+	if !e.CanUndo() {
+		t.Errorf("e.CanUndo() = false; want = true before the first edit is undone")
+	}
+	e.Undo()
+	if got := e.Text(); got != "" {
+		t.Errorf("after second Undo, e.Text() = %q; want = %q", got, "")
+	}
+	if e.CanUndo() {
+		t.Errorf("e.CanUndo() = true; want = false once every group is undone")
+	}
+
+	e.Redo()
+	e.Redo()
+	if got := e.Text(); got != "hello world" {
+		t.Fatalf("after redoing both groups, e.Text() = %s; want = %s", got, "hello world")
+	}
+	if got := e.CursorPos(); got != 11 {
+		t.Fatalf("after redoing both groups, e.CursorPos() = %d; want = %d", got, 11)
+	}
+	if e.CanRedo() {
+		t.Errorf("e.CanRedo() = true; want = false once every group is redone")
+	}
+
+	e.Draw(painter)
+	want = "\nhello world    \n...............\n...............\n...............\n...............\n"
+	if surface.String() != want {
+		t.Errorf("got = \n%s\n\nwant = \n%s", surface.String(), want)
+	}
+	if surface.cursor != (image.Point{11, 0}) {
+		t.Errorf("surface.cursor = %s; want = %s", surface.cursor, image.Point{11, 0})
+	}
+}
+
+func TestEntry_UndoClearedBySetText(t *testing.T) {
+	e := NewEntry()
+	e.SetFocused(true)
+	e.SetText("hello")
+	if e.CanUndo() {
+		t.Errorf("e.CanUndo() = true; want = false right after SetText")
+	}
+
+	for _, ch := range " world" {
+		e.OnEvent(Event{Type: EventKey, Ch: ch})
+	}
+	if !e.CanUndo() {
+		t.Fatalf("e.CanUndo() = false; want = true after typing")
+	}
+
+	e.SetText("replaced")
+	if e.CanUndo() || e.CanRedo() {
+		t.Errorf("SetText should clear undo/redo history, got CanUndo() = %v, CanRedo() = %v", e.CanUndo(), e.CanRedo())
+	}
+}
+
+func TestEntry_Draw_CombiningMark(t *testing.T) {
+	surface := newTestSurface(10, 1)
+	painter := NewPainter(surface, NewTheme())
+
+	e := NewEntry()
+	e.SetText("éx")
+	e.Resize(surface.size)
+	e.Draw(painter)
+
+	want := "\néx        \n"
+	if surface.String() != want {
+		t.Errorf("got = %q; want = %q", surface.String(), want)
+	}
+}
@@ -0,0 +1,58 @@
+package tui
+
+// EventType identifies the kind of Event delivered to a widget.
+type EventType int
+
+const (
+	// EventKey is sent for every key press.
+	EventKey EventType = iota
+	// EventResize is sent when the terminal window is resized.
+	EventResize
+)
+
+// Mod is a bitmask of modifier keys held down during an Event.
+type Mod int
+
+const (
+	ModShift Mod = 1 << iota
+	ModAlt
+	ModCtrl
+)
+
+// Key identifies a non-printable key.
+type Key int
+
+const (
+	// KeyNone is the zero value, used for events that carry a printable
+	// rune in Ch rather than a named Key.
+	KeyNone Key = iota
+	KeyEnter
+	KeyEsc
+	KeyTab
+	KeyBackspace
+	KeyBackspace2
+	KeyDelete
+	KeyArrowLeft
+	KeyArrowRight
+	KeyArrowUp
+	KeyArrowDown
+	KeyCtrlLeft
+	KeyCtrlRight
+	KeyHome
+	KeyEnd
+	KeyPgup
+	KeyPgdn
+	KeyCtrlW
+	KeyCtrlU
+	KeyCtrlZ
+	KeyCtrlY
+)
+
+// Event represents a single keyboard event delivered to the focused
+// widget.
+type Event struct {
+	Type EventType
+	Key  Key
+	Ch   rune
+	Mod  Mod
+}
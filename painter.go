@@ -0,0 +1,104 @@
+package tui
+
+import "image"
+
+// Surface is the drawing target a Painter writes cells to. The widget
+// tests implement it with testSurface.
+type Surface interface {
+	SetCell(x, y int, text string, s Style)
+	SetCursor(x, y int)
+	HideCursor()
+	Size() image.Point
+}
+
+// Painter draws widget content onto a Surface. It tracks the active
+// style class and the translation offset established by container
+// widgets so that children can draw in their own local coordinates.
+type Painter struct {
+	surface Surface
+	theme   *Theme
+
+	style  string
+	offset image.Point
+}
+
+// NewPainter returns a Painter that draws onto surface using theme.
+func NewPainter(surface Surface, theme *Theme) *Painter {
+	return &Painter{surface: surface, theme: theme}
+}
+
+// WithStyle invokes fn with the named style class active, restoring the
+// previously active style afterwards.
+func (p *Painter) WithStyle(name string, fn func(p *Painter)) {
+	prev := p.style
+	p.style = name
+	fn(p)
+	p.style = prev
+}
+
+// Translate shifts all subsequent drawing by (x, y). Every Translate
+// must be paired with a matching Restore.
+func (p *Painter) Translate(x, y int) {
+	p.offset = p.offset.Add(image.Point{x, y})
+}
+
+// Restore undoes the translation applied by a matching Translate call.
+func (p *Painter) Restore(x, y int) {
+	p.offset = p.offset.Sub(image.Point{x, y})
+}
+
+// FillRect paints a w x h rectangle at (x, y) with blank cells in the
+// current style.
+func (p *Painter) FillRect(x, y, w, h int) {
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			p.DrawRune(x+i, y+j, ' ')
+		}
+	}
+}
+
+// DrawRune paints a single rune at (x, y) in the current style.
+func (p *Painter) DrawRune(x, y int, r rune) {
+	p.DrawCluster(x, y, string(r))
+}
+
+// DrawCluster paints a grapheme cluster — a base rune plus any trailing
+// zero-width combining marks — as a single cell at (x, y). Callers that
+// walk runes by display width should use this instead of DrawRune so
+// combining marks attach to the cell they modify instead of overwriting
+// it.
+func (p *Painter) DrawCluster(x, y int, text string) {
+	pos := p.offset.Add(image.Point{x, y})
+	p.surface.SetCell(pos.X, pos.Y, text, p.theme.Style(p.style))
+}
+
+// DrawText paints s starting at (x, y) in the current style.
+func (p *Painter) DrawText(x, y int, s string) {
+	for i, r := range []rune(s) {
+		p.DrawRune(x+i, y, r)
+	}
+}
+
+// DrawCursor positions the terminal cursor at (x, y).
+func (p *Painter) DrawCursor(x, y int) {
+	pos := p.offset.Add(image.Point{x, y})
+	p.surface.SetCursor(pos.X, pos.Y)
+}
+
+// Offset returns the painter's current cumulative translation. Widgets
+// that float an overlay outside their own bounds use it to anchor the
+// overlay to their on-screen position.
+func (p *Painter) Offset() image.Point {
+	return p.offset
+}
+
+// DrawOverlay invokes fn with the painter translated to the absolute
+// surface position pos, ignoring whatever translation is currently
+// active. This lets a widget draw a floating element, such as a
+// completion popup, that escapes its parent's coordinate space.
+func (p *Painter) DrawOverlay(pos image.Point, fn func(p *Painter)) {
+	saved := p.offset
+	p.offset = pos
+	fn(p)
+	p.offset = saved
+}
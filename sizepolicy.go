@@ -0,0 +1,14 @@
+package tui
+
+// SizePolicy describes how a widget should be resized within a layout
+// along a single axis.
+type SizePolicy int
+
+const (
+	// Minimum widgets are never resized beyond their SizeHint.
+	Minimum SizePolicy = iota
+	// Preferred widgets may grow or shrink but prefer their SizeHint.
+	Preferred
+	// Expanding widgets consume any extra space available in the layout.
+	Expanding
+)
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"bytes"
+	"image"
+)
+
+type testCell struct {
+	Text  string
+	Style Style
+}
+
+// testSurface is a minimal in-memory Surface used by widget tests to
+// assert what got painted without depending on a real terminal.
+type testSurface struct {
+	size  image.Point
+	cells map[image.Point]testCell
+
+	cursor        image.Point
+	cursorVisible bool
+}
+
+func newTestSurface(w, h int) *testSurface {
+	return &testSurface{
+		size:  image.Point{w, h},
+		cells: make(map[image.Point]testCell),
+	}
+}
+
+func (s *testSurface) SetCell(x, y int, text string, st Style) {
+	s.cells[image.Point{x, y}] = testCell{Text: text, Style: st}
+}
+
+func (s *testSurface) SetCursor(x, y int) {
+	s.cursor = image.Point{x, y}
+	s.cursorVisible = true
+}
+
+func (s *testSurface) HideCursor() {
+	s.cursorVisible = false
+}
+
+func (s *testSurface) Size() image.Point {
+	return s.size
+}
+
+// String renders the surface as a grid of characters, using '.' for
+// cells that were never painted.
+func (s *testSurface) String() string {
+	var buf bytes.Buffer
+	buf.WriteByte('\n')
+	for y := 0; y < s.size.Y; y++ {
+		for x := 0; x < s.size.X; x++ {
+			c, ok := s.cells[image.Point{x, y}]
+			switch {
+			case !ok:
+				buf.WriteByte('.')
+			case c.Text == "":
+				buf.WriteByte(' ')
+			default:
+				buf.WriteString(c.Text)
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
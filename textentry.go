@@ -0,0 +1,430 @@
+package tui
+
+import (
+	"image"
+	"strings"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// WrapMode controls how a TextEntry breaks a logical line across the
+// widget's visible width.
+type WrapMode int
+
+const (
+	// WrapNone never breaks a logical line.
+	WrapNone WrapMode = iota
+	// WrapWord breaks at the last space that still fits, falling back
+	// to a hard break when a single word is wider than the line.
+	WrapWord
+	// WrapChar always breaks at the last rune that fits.
+	WrapChar
+)
+
+var _ Widget = &TextEntry{}
+
+// visualLine is one rendered row of a TextEntry: a (possibly wrapped)
+// slice of a single logical line.
+type visualLine struct {
+	row      int
+	startCol int
+	text     []rune
+}
+
+// TextEntry is a multi-line text editor, suitable for composing
+// messages or editing short blocks of text.
+type TextEntry struct {
+	WidgetBase
+
+	lines []string
+
+	cursorRow int
+	cursorCol int
+
+	scrollOffset int
+
+	wrap WrapMode
+
+	submitKey Key
+	submitMod Mod
+
+	onChanged func(*TextEntry)
+	onSubmit  func(*TextEntry)
+}
+
+// NewTextEntry returns a new, empty TextEntry. Ctrl+Enter triggers
+// OnSubmit by default; plain Enter inserts a newline.
+func NewTextEntry() *TextEntry {
+	return &TextEntry{
+		lines:     []string{""},
+		submitKey: KeyEnter,
+		submitMod: ModCtrl,
+	}
+}
+
+// Text returns the full contents of the entry, with logical lines
+// joined by '\n'.
+func (e *TextEntry) Text() string {
+	return strings.Join(e.lines, "\n")
+}
+
+// SetText replaces the contents of the entry and moves the cursor to
+// the end of the new text.
+func (e *TextEntry) SetText(text string) {
+	e.lines = strings.Split(text, "\n")
+	e.cursorRow = len(e.lines) - 1
+	e.cursorCol = len([]rune(e.lines[e.cursorRow]))
+	e.notifyChanged()
+}
+
+// Lines returns the entry's logical lines, split on hard newlines.
+func (e *TextEntry) Lines() []string {
+	lines := make([]string, len(e.lines))
+	copy(lines, e.lines)
+	return lines
+}
+
+// SetCursor moves the cursor to the given logical row and rune column,
+// clamping both to the bounds of the text.
+func (e *TextEntry) SetCursor(row, col int) {
+	e.cursorRow = clampInt(row, 0, len(e.lines)-1)
+	e.cursorCol = clampInt(col, 0, len([]rune(e.lines[e.cursorRow])))
+}
+
+// SetWrapMode sets how long lines are wrapped when drawn.
+func (e *TextEntry) SetWrapMode(w WrapMode) {
+	e.wrap = w
+}
+
+// SetSubmitKey configures which key, held with which modifier, triggers
+// OnSubmit instead of inserting a newline. The default is Ctrl+Enter.
+func (e *TextEntry) SetSubmitKey(key Key, mod Mod) {
+	e.submitKey = key
+	e.submitMod = mod
+}
+
+// OnChanged sets a handler that's invoked whenever the contents of the
+// entry change.
+func (e *TextEntry) OnChanged(fn func(*TextEntry)) {
+	e.onChanged = fn
+}
+
+// OnSubmit sets a handler that's invoked when the configured submit key
+// is pressed while the entry is focused.
+func (e *TextEntry) OnSubmit(fn func(*TextEntry)) {
+	e.onSubmit = fn
+}
+
+// SizeHint returns the width of the entry's longest line, but at least
+// 1, and its number of logical lines.
+func (e *TextEntry) SizeHint() image.Point {
+	var width int
+	for _, l := range e.lines {
+		if w := runewidth.StringWidth(l); w > width {
+			width = w
+		}
+	}
+	if width < 1 {
+		width = 1
+	}
+	return image.Point{width, len(e.lines)}
+}
+
+// OnEvent handles a key event: moving the two-dimensional cursor,
+// editing the text, or triggering the submit handler.
+func (e *TextEntry) OnEvent(ev Event) {
+	if !e.IsFocused() || ev.Type != EventKey {
+		return
+	}
+
+	if ev.Key == e.submitKey && ev.Mod&e.submitMod == e.submitMod {
+		if e.onSubmit != nil {
+			e.onSubmit(e)
+		}
+		return
+	}
+
+	switch ev.Key {
+	case KeyEnter:
+		e.insertNewline()
+		return
+	case KeyArrowLeft:
+		e.moveCursor(0, -1)
+		return
+	case KeyArrowRight:
+		e.moveCursor(0, 1)
+		return
+	case KeyArrowUp:
+		e.moveCursor(-1, 0)
+		return
+	case KeyArrowDown:
+		e.moveCursor(1, 0)
+		return
+	case KeyPgup:
+		e.moveCursor(-e.Size().Y, 0)
+		return
+	case KeyPgdn:
+		e.moveCursor(e.Size().Y, 0)
+		return
+	case KeyHome:
+		e.cursorCol = 0
+		return
+	case KeyEnd:
+		e.cursorCol = len([]rune(e.lines[e.cursorRow]))
+		return
+	case KeyBackspace, KeyBackspace2:
+		e.backspace()
+		return
+	case KeyDelete:
+		e.delete()
+		return
+	}
+
+	if ev.Ch != 0 {
+		e.insert(ev.Ch)
+	}
+}
+
+// Draw draws the entry, wrapping long lines per SetWrapMode and
+// scrolling vertically to keep the cursor on screen.
+func (e *TextEntry) Draw(p *Painter) {
+	style := "textentry"
+	if e.IsFocused() {
+		style += ".focused"
+	}
+
+	size := e.Size()
+
+	p.WithStyle(style, func(p *Painter) {
+		p.FillRect(0, 0, size.X, size.Y)
+
+		visual := e.wrapLines(size.X)
+		cursorVisual, cursorX := e.cursorPosition(visual)
+		e.scrollOffset = e.adjustScroll(e.scrollOffset, cursorVisual, size.Y)
+
+		for y := 0; y < size.Y; y++ {
+			vi := e.scrollOffset + y
+			if vi >= len(visual) {
+				break
+			}
+
+			x := 0
+			line := visual[vi].text
+			for i := 0; i < len(line) && x < size.X; {
+				text, w, next := nextCluster(line, i)
+				p.DrawCluster(x, y, text)
+				x += w
+				i = next
+			}
+		}
+
+		if e.IsFocused() {
+			p.DrawCursor(cursorX, cursorVisual-e.scrollOffset)
+		}
+	})
+}
+
+// wrapLines splits every logical line into one or more visualLines no
+// wider than width, honouring the entry's WrapMode. With WrapNone each
+// logical line becomes exactly one visualLine, however wide it is.
+func (e *TextEntry) wrapLines(width int) []visualLine {
+	var out []visualLine
+
+	for row, line := range e.lines {
+		runes := []rune(line)
+
+		if width <= 0 || e.wrap == WrapNone || len(runes) == 0 {
+			out = append(out, visualLine{row: row, text: runes})
+			continue
+		}
+
+		start := 0
+		for start < len(runes) {
+			end := e.breakPoint(runes, start, width)
+			out = append(out, visualLine{row: row, startCol: start, text: runes[start:end]})
+			start = end
+		}
+	}
+
+	return out
+}
+
+// breakPoint returns the rune index, exclusive, where the visual line
+// starting at start should end so that it fits within width columns.
+func (e *TextEntry) breakPoint(runes []rune, start, width int) int {
+	col := 0
+	end := start
+	lastSpace := -1
+
+	for end < len(runes) {
+		w := runewidth.RuneWidth(runes[end])
+		if col+w > width {
+			break
+		}
+		col += w
+		if runes[end] == ' ' {
+			lastSpace = end + 1
+		}
+		end++
+	}
+
+	if end == start {
+		// Not even one rune fits; force progress rather than loop forever.
+		return start + 1
+	}
+	if e.wrap == WrapWord && end < len(runes) && lastSpace > start {
+		return lastSpace
+	}
+	return end
+}
+
+// cursorPosition returns the index into visual holding the cursor and
+// the visual column, in cells, at which it should be drawn.
+func (e *TextEntry) cursorPosition(visual []visualLine) (int, int) {
+	for i, v := range visual {
+		if v.row != e.cursorRow {
+			continue
+		}
+
+		end := v.startCol + len(v.text)
+		last := i == len(visual)-1 || visual[i+1].row != v.row
+		if e.cursorCol >= v.startCol && (e.cursorCol < end || last) {
+			return i, runewidth.StringWidth(string(v.text[:e.cursorCol-v.startCol]))
+		}
+	}
+	return 0, 0
+}
+
+// cellToCol returns the rune column within v's logical line that sits at
+// the given visual cell offset, clamped to v's own span. It's the
+// inverse of the cell math in cursorPosition, used to carry the cursor's
+// horizontal position across a vertical move between visual lines.
+func (e *TextEntry) cellToCol(v visualLine, cell int) int {
+	col := 0
+	for i, r := range v.text {
+		w := runewidth.RuneWidth(r)
+		if col+w > cell {
+			return v.startCol + i
+		}
+		col += w
+	}
+	return v.startCol + len(v.text)
+}
+
+// adjustScroll returns the scroll offset needed to keep cursorVisual
+// within the visible height, moving it as little as possible.
+func (e *TextEntry) adjustScroll(scroll, cursorVisual, height int) int {
+	if height <= 0 {
+		return scroll
+	}
+	if cursorVisual < scroll {
+		return cursorVisual
+	}
+	if cursorVisual >= scroll+height {
+		return cursorVisual - height + 1
+	}
+	return scroll
+}
+
+func (e *TextEntry) notifyChanged() {
+	if e.onChanged != nil {
+		e.onChanged(e)
+	}
+}
+
+func (e *TextEntry) moveCursor(dRow, dCol int) {
+	if dRow != 0 {
+		// Up/Down/PageUp/PageDown move by visual (wrapped) line, not by
+		// logical line, so they can reach every wrapped continuation row.
+		visual := e.wrapLines(e.Size().X)
+		if len(visual) == 0 {
+			return
+		}
+		vi, cell := e.cursorPosition(visual)
+		vi = clampInt(vi+dRow, 0, len(visual)-1)
+		v := visual[vi]
+		e.cursorRow = v.row
+		e.cursorCol = e.cellToCol(v, cell)
+		return
+	}
+
+	col := e.cursorCol + dCol
+	switch {
+	case col < 0:
+		if e.cursorRow == 0 {
+			col = 0
+		} else {
+			e.cursorRow--
+			col = len([]rune(e.lines[e.cursorRow]))
+		}
+	case col > len([]rune(e.lines[e.cursorRow])):
+		if e.cursorRow == len(e.lines)-1 {
+			col = len([]rune(e.lines[e.cursorRow]))
+		} else {
+			e.cursorRow++
+			col = 0
+		}
+	}
+	e.cursorCol = col
+}
+
+func (e *TextEntry) insertNewline() {
+	line := []rune(e.lines[e.cursorRow])
+	before := string(line[:e.cursorCol])
+	after := string(line[e.cursorCol:])
+
+	e.lines[e.cursorRow] = before
+	e.lines = append(e.lines, "")
+	copy(e.lines[e.cursorRow+2:], e.lines[e.cursorRow+1:])
+	e.lines[e.cursorRow+1] = after
+
+	e.cursorRow++
+	e.cursorCol = 0
+	e.notifyChanged()
+}
+
+func (e *TextEntry) insert(r rune) {
+	line := []rune(e.lines[e.cursorRow])
+	line = append(line[:e.cursorCol:e.cursorCol], append([]rune{r}, line[e.cursorCol:]...)...)
+	e.lines[e.cursorRow] = string(line)
+	e.cursorCol++
+	e.notifyChanged()
+}
+
+func (e *TextEntry) backspace() {
+	if e.cursorCol > 0 {
+		line := []rune(e.lines[e.cursorRow])
+		line = append(line[:e.cursorCol-1], line[e.cursorCol:]...)
+		e.lines[e.cursorRow] = string(line)
+		e.cursorCol--
+		e.notifyChanged()
+		return
+	}
+	if e.cursorRow == 0 {
+		return
+	}
+
+	prevLen := len([]rune(e.lines[e.cursorRow-1]))
+	e.lines[e.cursorRow-1] += e.lines[e.cursorRow]
+	e.lines = append(e.lines[:e.cursorRow], e.lines[e.cursorRow+1:]...)
+	e.cursorRow--
+	e.cursorCol = prevLen
+	e.notifyChanged()
+}
+
+func (e *TextEntry) delete() {
+	line := []rune(e.lines[e.cursorRow])
+	if e.cursorCol < len(line) {
+		line = append(line[:e.cursorCol], line[e.cursorCol+1:]...)
+		e.lines[e.cursorRow] = string(line)
+		e.notifyChanged()
+		return
+	}
+	if e.cursorRow == len(e.lines)-1 {
+		return
+	}
+
+	e.lines[e.cursorRow] += e.lines[e.cursorRow+1]
+	e.lines = append(e.lines[:e.cursorRow+1], e.lines[e.cursorRow+2:]...)
+	e.notifyChanged()
+}
@@ -0,0 +1,240 @@
+package tui
+
+import (
+	"image"
+	"testing"
+)
+
+var textEntrySizeTests = []struct {
+	test        string
+	setup       func() *TextEntry
+	minSizeHint image.Point
+	sizeHint    image.Point
+}{
+	{
+		test: "TextEntry default size",
+		setup: func() *TextEntry {
+			return NewTextEntry()
+		},
+		minSizeHint: image.Point{1, 1},
+		sizeHint:    image.Point{1, 1},
+	},
+	{
+		test: "TextEntry with content",
+		setup: func() *TextEntry {
+			e := NewTextEntry()
+			e.SetText("hello\nworld!!")
+			return e
+		},
+		minSizeHint: image.Point{1, 1},
+		sizeHint:    image.Point{7, 2},
+	},
+}
+
+func TestTextEntry_Size(t *testing.T) {
+	for _, tt := range textEntrySizeTests {
+		e := tt.setup()
+
+		if got := e.SizeHint(); got != tt.sizeHint {
+			t.Errorf("e.SizeHint() = %s; want = %s", got, tt.sizeHint)
+		}
+		if got := e.MinSizeHint(); got != tt.minSizeHint {
+			t.Errorf("e.MinSizeHint() = %s; want = %s", got, tt.minSizeHint)
+		}
+	}
+}
+
+var drawTextEntryTests = []struct {
+	test  string
+	size  image.Point
+	setup func() *TextEntry
+	want  string
+}{
+	{
+		test: "Empty text entry",
+		size: image.Point{10, 3},
+		setup: func() *TextEntry {
+			return NewTextEntry()
+		},
+		want: "\n          \n          \n          \n",
+	},
+	{
+		test: "Text entry with hard newline",
+		size: image.Point{10, 3},
+		setup: func() *TextEntry {
+			e := NewTextEntry()
+			e.SetText("hello\nworld")
+			return e
+		},
+		want: "\nhello     \nworld     \n          \n",
+	},
+	{
+		test: "Word wrapping",
+		size: image.Point{6, 3},
+		setup: func() *TextEntry {
+			e := NewTextEntry()
+			e.SetWrapMode(WrapWord)
+			e.SetText("hello world foo")
+			return e
+		},
+		want: "\nhello \nworld \nfoo   \n",
+	},
+	{
+		test: "Combining mark attaches to its base rune",
+		size: image.Point{10, 1},
+		setup: func() *TextEntry {
+			e := NewTextEntry()
+			// "é" is the decomposed form of "é": a base rune
+			// followed by a zero-width combining acute accent.
+			e.SetText("éx")
+			return e
+		},
+		want: "\néx        \n",
+	},
+}
+
+func TestTextEntry_Draw(t *testing.T) {
+	for _, tt := range drawTextEntryTests {
+		tt := tt
+		t.Run(tt.test, func(t *testing.T) {
+			surface := newTestSurface(tt.size.X, tt.size.Y)
+			painter := NewPainter(surface, NewTheme())
+
+			e := tt.setup()
+			e.Resize(surface.size)
+			e.Draw(painter)
+
+			if surface.String() != tt.want {
+				t.Errorf("got = \n%s\n\nwant = \n%s", surface.String(), tt.want)
+			}
+		})
+	}
+}
+
+var cursorTextEntryTests = []struct {
+	test   string
+	size   image.Point
+	setup  func() *TextEntry
+	cursor image.Point
+}{
+	{
+		test: "Cursor after hard newline",
+		size: image.Point{10, 3},
+		setup: func() *TextEntry {
+			e := NewTextEntry()
+			e.SetFocused(true)
+			e.SetText("hello\nworld")
+			return e
+		},
+		cursor: image.Point{5, 1},
+	},
+	{
+		test: "Cursor after word wrap",
+		size: image.Point{6, 3},
+		setup: func() *TextEntry {
+			e := NewTextEntry()
+			e.SetFocused(true)
+			e.SetWrapMode(WrapWord)
+			e.SetText("hello world foo")
+			return e
+		},
+		cursor: image.Point{3, 2},
+	},
+}
+
+func TestTextEntry_Cursor(t *testing.T) {
+	for _, tt := range cursorTextEntryTests {
+		tt := tt
+		t.Run(tt.test, func(t *testing.T) {
+			surface := newTestSurface(tt.size.X, tt.size.Y)
+			painter := NewPainter(surface, NewTheme())
+
+			e := tt.setup()
+			e.Resize(surface.size)
+			e.Draw(painter)
+
+			if surface.cursor != tt.cursor {
+				t.Errorf("surface.cursor = %s; want = %s", surface.cursor, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestTextEntry_OnSubmit(t *testing.T) {
+	e := NewTextEntry()
+	e.SetFocused(true)
+
+	var submitted bool
+	e.OnSubmit(func(e *TextEntry) {
+		submitted = true
+	})
+
+	e.OnEvent(Event{Type: EventKey, Key: KeyEnter})
+	if submitted {
+		t.Errorf("plain Enter should insert a newline, not submit")
+	}
+	if got := e.Lines(); len(got) != 2 {
+		t.Errorf("e.Lines() = %v; want 2 lines after Enter", got)
+	}
+
+	e.OnEvent(Event{Type: EventKey, Key: KeyEnter, Mod: ModCtrl})
+	if !submitted {
+		t.Errorf("Ctrl+Enter should submit")
+	}
+}
+
+func TestTextEntry_VerticalNavigation(t *testing.T) {
+	surface := newTestSurface(6, 3)
+	painter := NewPainter(surface, NewTheme())
+
+	e := NewTextEntry()
+	e.SetFocused(true)
+	e.SetWrapMode(WrapWord)
+	e.SetText("hello world foo")
+	e.Resize(surface.size)
+
+	// The whole logical line wraps into 3 visual rows: "hello ",
+	// "world " and "foo". The cursor starts at the end of the text, on
+	// the last wrapped row.
+	e.Draw(painter)
+	if surface.cursor != (image.Point{3, 2}) {
+		t.Fatalf("surface.cursor = %s; want = %s", surface.cursor, image.Point{3, 2})
+	}
+
+	// Up must reach the earlier wrapped rows of the same logical line,
+	// not just move between logical lines.
+	e.OnEvent(Event{Type: EventKey, Key: KeyArrowUp})
+	e.Draw(painter)
+	if surface.cursor != (image.Point{3, 1}) {
+		t.Errorf("after first Up, surface.cursor = %s; want = %s", surface.cursor, image.Point{3, 1})
+	}
+
+	e.OnEvent(Event{Type: EventKey, Key: KeyArrowUp})
+	e.Draw(painter)
+	if surface.cursor != (image.Point{3, 0}) {
+		t.Errorf("after second Up, surface.cursor = %s; want = %s", surface.cursor, image.Point{3, 0})
+	}
+
+	e.OnEvent(Event{Type: EventKey, Key: KeyArrowDown})
+	e.OnEvent(Event{Type: EventKey, Key: KeyArrowDown})
+	e.Draw(painter)
+	if surface.cursor != (image.Point{3, 2}) {
+		t.Errorf("after two Down, surface.cursor = %s; want = %s", surface.cursor, image.Point{3, 2})
+	}
+}
+
+func TestTextEntry_Lines(t *testing.T) {
+	e := NewTextEntry()
+	e.SetText("one\ntwo\nthree")
+
+	lines := e.Lines()
+	want := []string{"one", "two", "three"}
+	if len(lines) != len(want) {
+		t.Fatalf("e.Lines() = %v; want = %v", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("e.Lines()[%d] = %s; want = %s", i, lines[i], want[i])
+		}
+	}
+}
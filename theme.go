@@ -0,0 +1,56 @@
+package tui
+
+// Color is an abstract terminal color, resolved to the backend's native
+// representation by the Surface implementation.
+type Color int
+
+const (
+	ColorDefault Color = iota
+	ColorBlack
+	ColorRed
+	ColorGreen
+	ColorYellow
+	ColorBlue
+	ColorMagenta
+	ColorCyan
+	ColorWhite
+)
+
+// Style describes how a run of cells belonging to a style class should
+// be painted.
+type Style struct {
+	Fg, Bg    Color
+	Bold      bool
+	Underline bool
+	Reverse   bool
+}
+
+// Theme maps style class names, such as "entry" or "entry.focused", to
+// the Style used to paint them.
+type Theme struct {
+	styles map[string]Style
+}
+
+// NewTheme returns a Theme populated with tui-go's default styles.
+func NewTheme() *Theme {
+	t := &Theme{styles: make(map[string]Style)}
+	t.SetStyle("entry", Style{})
+	t.SetStyle("entry.focused", Style{Reverse: true})
+	t.SetStyle("entry.invalid", Style{Fg: ColorRed})
+	t.SetStyle("textentry", Style{})
+	t.SetStyle("textentry.focused", Style{Reverse: true})
+	t.SetStyle("entry.completion", Style{})
+	t.SetStyle("entry.completion.selected", Style{Reverse: true})
+	return t
+}
+
+// SetStyle assigns the Style used for the given style class.
+func (t *Theme) SetStyle(name string, s Style) {
+	t.styles[name] = s
+}
+
+// Style returns the Style registered for name, or the zero Style if none
+// has been set.
+func (t *Theme) Style(name string) Style {
+	return t.styles[name]
+}
@@ -0,0 +1,30 @@
+package tui
+
+import runewidth "github.com/mattn/go-runewidth"
+
+// clampInt restricts v to the inclusive range [min, max].
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// nextCluster returns the grapheme cluster starting at runes[i]: the
+// base rune together with any zero-width runes (such as combining
+// marks) that immediately follow it. It returns the cluster's text, its
+// display width, and the index of the next cluster's base rune, so a
+// draw loop can advance by cluster instead of by rune and keep
+// combining marks attached to the cell they modify instead of
+// overwriting it.
+func nextCluster(runes []rune, i int) (text string, width int, next int) {
+	width = runewidth.RuneWidth(runes[i])
+	next = i + 1
+	for next < len(runes) && runewidth.RuneWidth(runes[next]) == 0 {
+		next++
+	}
+	return string(runes[i:next]), width, next
+}
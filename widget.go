@@ -0,0 +1,80 @@
+package tui
+
+import "image"
+
+// Widget is the interface implemented by values that can be drawn and
+// laid out inside a UI.
+type Widget interface {
+	Draw(p *Painter)
+
+	MinSizeHint() image.Point
+	SizeHint() image.Point
+	Size() image.Point
+	Resize(size image.Point)
+
+	SizePolicy() (horizontal, vertical SizePolicy)
+	SetSizePolicy(horizontal, vertical SizePolicy)
+
+	IsFocused() bool
+	SetFocused(focused bool)
+
+	OnEvent(ev Event)
+}
+
+// WidgetBase implements the bookkeeping shared by every Widget. It is
+// meant to be embedded by concrete widgets, not used on its own.
+type WidgetBase struct {
+	size image.Point
+
+	focused bool
+
+	sizePolicyX SizePolicy
+	sizePolicyY SizePolicy
+}
+
+// Size returns the current size of the widget.
+func (w *WidgetBase) Size() image.Point {
+	return w.size
+}
+
+// Resize sets the size of the widget.
+func (w *WidgetBase) Resize(size image.Point) {
+	w.size = size
+}
+
+// MinSizeHint returns the minimum size the widget is willing to be drawn
+// at. The default is a single cell.
+func (w *WidgetBase) MinSizeHint() image.Point {
+	return image.Point{1, 1}
+}
+
+// SizeHint returns the widget's preferred size. The default falls back
+// to MinSizeHint; widgets with real content should override it.
+func (w *WidgetBase) SizeHint() image.Point {
+	return w.MinSizeHint()
+}
+
+// SizePolicy returns the widget's current horizontal and vertical size
+// policies.
+func (w *WidgetBase) SizePolicy() (SizePolicy, SizePolicy) {
+	return w.sizePolicyX, w.sizePolicyY
+}
+
+// SetSizePolicy sets the widget's horizontal and vertical size policies.
+func (w *WidgetBase) SetSizePolicy(horizontal, vertical SizePolicy) {
+	w.sizePolicyX = horizontal
+	w.sizePolicyY = vertical
+}
+
+// IsFocused reports whether the widget currently has keyboard focus.
+func (w *WidgetBase) IsFocused() bool {
+	return w.focused
+}
+
+// SetFocused sets whether the widget has keyboard focus.
+func (w *WidgetBase) SetFocused(focused bool) {
+	w.focused = focused
+}
+
+// OnEvent is a no-op by default. Widgets that handle input override it.
+func (w *WidgetBase) OnEvent(ev Event) {}